@@ -0,0 +1,56 @@
+// Package api exposes the avatar processing service over a gorilla/mux
+// REST API.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"backend-interview-challenge/avatar"
+)
+
+// RestApi mounts the avatar job endpoints on a *mux.Router, following the
+// same MountRoutes convention as cc-backend's RestApi.
+type RestApi struct {
+	service  *avatar.AvatarProcessingService
+	apiToken string
+}
+
+// NewRestApi creates a RestApi backed by service. apiToken is the bearer
+// token required on every request; an empty token disables auth, which
+// should only be used in local development.
+func NewRestApi(service *avatar.AvatarProcessingService, apiToken string) *RestApi {
+	return &RestApi{service: service, apiToken: apiToken}
+}
+
+// MountRoutes registers every avatar job route on router.
+func (api *RestApi) MountRoutes(router *mux.Router) {
+	r := router.PathPrefix("/api/v1").Subrouter()
+	r.Use(api.authMiddleware)
+
+	r.HandleFunc("/jobs", api.submitJob).Methods(http.MethodPost)
+	r.HandleFunc("/jobs", api.listJobs).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/metrics", api.jobMetrics).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}", api.getJob).Methods(http.MethodGet)
+	r.HandleFunc("/jobs/{id}/tags", api.updateJobTags).Methods(http.MethodPatch)
+	r.HandleFunc("/users/{name}/avatar", api.getUserAvatar).Methods(http.MethodGet)
+}
+
+// errorResponse is the structured JSON body returned for every error.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"backend-interview-challenge/avatar"
+)
+
+// submitJobRequest is the JSON body accepted by POST /api/v1/jobs.
+type submitJobRequest struct {
+	UserID    string `json:"user_id"`
+	InputData string `json:"input_data"`
+}
+
+func (api *RestApi) submitJob(w http.ResponseWriter, r *http.Request) {
+	var req submitJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.UserID == "" || req.InputData == "" {
+		writeError(w, http.StatusBadRequest, "user_id and input_data are required")
+		return
+	}
+
+	job, err := api.service.SubmitJob(req.UserID, req.InputData)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (api *RestApi) getJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	job, err := api.service.GetJobStatus(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if job == nil {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// jobListResponse is the JSON body returned by GET /api/v1/jobs.
+type jobListResponse struct {
+	Jobs   []*avatar.AvatarJob `json:"jobs"`
+	Total  int                 `json:"total"`
+	Offset int                 `json:"offset"`
+	Limit  int                 `json:"limit"`
+}
+
+func (api *RestApi) listJobs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := avatar.JobFilter{
+		UserID: q.Get("user_id"),
+		Status: q.Get("status"),
+		Offset: parseIntOr(q.Get("offset"), 0),
+		Limit:  parseIntOr(q.Get("limit"), 50),
+	}
+
+	if from := q.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "from must be RFC3339")
+			return
+		}
+		filter.From = t
+	}
+	if to := q.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "to must be RFC3339")
+			return
+		}
+		filter.To = t
+	}
+
+	if afterID := q.Get("after_id"); afterID != "" {
+		after, err := time.Parse(time.RFC3339, q.Get("after"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "after must be RFC3339 and set together with after_id")
+			return
+		}
+		filter.After = after
+		filter.AfterID = afterID
+	}
+
+	jobs, total, err := api.service.ListJobs(filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobListResponse{
+		Jobs:   jobs,
+		Total:  total,
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	})
+}
+
+// updateTagsRequest is the JSON body accepted by PATCH /api/v1/jobs/{id}/tags.
+type updateTagsRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+func (api *RestApi) updateJobTags(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req updateTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	job, err := api.service.UpdateTags(id, req.Add, req.Remove)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (api *RestApi) jobMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics, err := api.service.Metrics()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, metrics)
+}
+
+func (api *RestApi) getUserAvatar(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	body, contentType, err := api.service.GetUserAvatar(name)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func parseIntOr(s string, fallback int) int {
+	if s == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
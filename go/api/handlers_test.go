@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"backend-interview-challenge/avatar"
+)
+
+// fakeAvatarStorage is a minimal in-memory avatar.AvatarStorage for tests
+// that never exercise the real S3/GCS backends.
+type fakeAvatarStorage struct{}
+
+func (fakeAvatarStorage) Upload(_ context.Context, key string, data []byte) (string, string, error) {
+	return "https://avatars.test/" + key, "private", nil
+}
+
+func (fakeAvatarStorage) Download(_ context.Context, key string) ([]byte, string, error) {
+	return nil, "", &avatar.ModerationError{Code: "not_found", Message: "no object " + key}
+}
+
+func newTestRouter(t *testing.T, apiToken string) *mux.Router {
+	t.Helper()
+	service := avatar.NewAvatarProcessingService(
+		"http://moderation.invalid",
+		"moderation-token",
+		1, 8,
+		nil,
+		fakeAvatarStorage{},
+		avatar.NewMemoryJobRepository(),
+	)
+
+	router := mux.NewRouter()
+	NewRestApi(service, apiToken).MountRoutes(router)
+	return router
+}
+
+func doRequest(router *mux.Router, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSubmitAndGetJob(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	rec := doRequest(router, http.MethodPost, "/api/v1/jobs", "", submitJobRequest{UserID: "user-1", InputData: "a robot avatar"})
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("submitJob status = %d, want 202: %s", rec.Code, rec.Body.String())
+	}
+	var submitted avatar.AvatarJob
+	if err := json.Unmarshal(rec.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("decode submit response: %v", err)
+	}
+	if submitted.ID == "" || submitted.Status != "pending" {
+		t.Fatalf("submitted job = %+v, want a pending job with an ID", submitted)
+	}
+
+	rec = doRequest(router, http.MethodGet, "/api/v1/jobs/"+submitted.ID, "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("getJob status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var got avatar.AvatarJob
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode get response: %v", err)
+	}
+	if got.ID != submitted.ID {
+		t.Fatalf("getJob returned %+v, want job %s", got, submitted.ID)
+	}
+}
+
+func TestSubmitJobMissingFields(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	rec := doRequest(router, http.MethodPost, "/api/v1/jobs", "", submitJobRequest{UserID: "user-1"})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestGetJobNotFound(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	rec := doRequest(router, http.MethodGet, "/api/v1/jobs/does-not-exist", "", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	doRequest(router, http.MethodPost, "/api/v1/jobs", "", submitJobRequest{UserID: "user-1", InputData: "a robot avatar"})
+	doRequest(router, http.MethodPost, "/api/v1/jobs", "", submitJobRequest{UserID: "user-2", InputData: "a cat avatar"})
+
+	rec := doRequest(router, http.MethodGet, "/api/v1/jobs", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("listJobs status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var resp jobListResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode list response: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Jobs) != 2 {
+		t.Fatalf("listJobs = %+v, want 2 jobs", resp)
+	}
+}
+
+func TestUpdateJobTags(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	rec := doRequest(router, http.MethodPost, "/api/v1/jobs", "", submitJobRequest{UserID: "user-1", InputData: "a robot avatar"})
+	var submitted avatar.AvatarJob
+	json.Unmarshal(rec.Body.Bytes(), &submitted)
+
+	rec = doRequest(router, http.MethodPatch, "/api/v1/jobs/"+submitted.ID+"/tags", "", updateTagsRequest{Add: []string{"featured"}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("updateJobTags status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var tagged avatar.AvatarJob
+	if err := json.Unmarshal(rec.Body.Bytes(), &tagged); err != nil {
+		t.Fatalf("decode tags response: %v", err)
+	}
+	if len(tagged.Tags) != 1 || tagged.Tags[0] != "featured" {
+		t.Fatalf("Tags = %v, want [featured]", tagged.Tags)
+	}
+}
+
+func TestJobMetrics(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	doRequest(router, http.MethodPost, "/api/v1/jobs", "", submitJobRequest{UserID: "user-1", InputData: "a robot avatar"})
+
+	rec := doRequest(router, http.MethodGet, "/api/v1/jobs/metrics", "", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("jobMetrics status = %d, want 200: %s", rec.Code, rec.Body.String())
+	}
+	var metrics avatar.JobMetrics
+	if err := json.Unmarshal(rec.Body.Bytes(), &metrics); err != nil {
+		t.Fatalf("decode metrics response: %v", err)
+	}
+	if metrics.CountByStatus["pending"] != 1 {
+		t.Fatalf("CountByStatus = %+v, want pending:1", metrics.CountByStatus)
+	}
+}
+
+func TestGetUserAvatarNotFound(t *testing.T) {
+	router := newTestRouter(t, "")
+
+	rec := doRequest(router, http.MethodGet, "/api/v1/users/user-1/avatar", "", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	router := newTestRouter(t, "s3cr3t")
+
+	rec := doRequest(router, http.MethodGet, "/api/v1/jobs", "", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status without a token = %d, want 401", rec.Code)
+	}
+
+	rec = doRequest(router, http.MethodGet, "/api/v1/jobs", "wrong", nil)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status with a wrong token = %d, want 401", rec.Code)
+	}
+
+	rec = doRequest(router, http.MethodGet, "/api/v1/jobs", "s3cr3t", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status with the correct token = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// authMiddleware enforces a "Bearer <token>" Authorization header matching
+// api.apiToken, mirroring the moderation API's own token style. It is a
+// no-op when apiToken is empty.
+func (api *RestApi) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if api.apiToken == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == "" || token == header || token != api.apiToken {
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,27 @@
+package avatar
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetUserAvatar fetches the bytes of the most recently completed avatar for
+// userID, along with its detected content type, so the API layer can stream
+// it back to the caller without knowing where it is actually stored. It
+// reads through AvatarStorage.Download rather than OutputURL directly, so
+// it works regardless of the configured storage ACL.
+func (s *AvatarProcessingService) GetUserAvatar(userID string) ([]byte, string, error) {
+	jobs, _, err := s.ListJobs(JobFilter{UserID: userID, Status: "completed"})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(jobs) == 0 || jobs[0].OutputURL == nil {
+		return nil, "", fmt.Errorf("avatar: no completed avatar for user %s", userID)
+	}
+
+	data, contentType, err := s.storage.Download(context.Background(), avatarStorageKey(jobs[0].ID))
+	if err != nil {
+		return nil, "", fmt.Errorf("avatar: fetch avatar for user %s: %w", userID, err)
+	}
+	return data, contentType, nil
+}
@@ -0,0 +1,100 @@
+package avatar
+
+import (
+	"sync"
+	"time"
+)
+
+// breakerState is one of the three states of a hand-rolled half-open
+// circuit breaker state machine.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker trips to open after consecutive failures and short-circuits
+// calls until cooldown elapses, then allows a single trial call through in
+// the half-open state to decide whether to close again.
+type CircuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu        sync.Mutex
+	state     breakerState
+	failures  int
+	openUntil time.Time
+}
+
+// NewCircuitBreaker creates a CircuitBreaker that opens after
+// failureThreshold consecutive failures and stays open for cooldown before
+// trying a half-open probe call.
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	return &CircuitBreaker{failureThreshold: failureThreshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call should be attempted. It transitions the
+// breaker from open to half-open once cooldown has elapsed, letting through
+// only the single call that causes that transition; every other caller
+// sees false until RecordSuccess or RecordFailure resolves the probe.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Now().Before(b.openUntil) {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordSuccess closes the breaker and resets its failure count.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+// RecordFailure increments the failure count and trips the breaker open
+// once failureThreshold consecutive failures have been recorded, or
+// immediately re-opens it if the half-open probe call failed.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.trip()
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.failureThreshold {
+		b.trip()
+	}
+}
+
+func (b *CircuitBreaker) trip() {
+	b.state = breakerOpen
+	b.failures = 0
+	b.openUntil = time.Now().Add(b.cooldown)
+}
+
+// IsOpen reports whether the breaker is currently blocking calls.
+func (b *CircuitBreaker) IsOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == breakerOpen && time.Now().Before(b.openUntil)
+}
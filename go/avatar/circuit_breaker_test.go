@@ -0,0 +1,84 @@
+package avatar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_ClosedAllowsCalls(t *testing.T) {
+	b := NewCircuitBreaker(3, time.Minute)
+	if !b.Allow() {
+		t.Fatalf("Allow on a fresh breaker = false, want true")
+	}
+	if b.IsOpen() {
+		t.Fatalf("IsOpen on a fresh breaker = true, want false")
+	}
+}
+
+func TestCircuitBreaker_TripsOpenAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker(2, time.Minute)
+	b.RecordFailure()
+	if b.IsOpen() {
+		t.Fatalf("IsOpen after 1 of 2 failures = true, want false")
+	}
+	b.RecordFailure()
+	if !b.IsOpen() {
+		t.Fatalf("IsOpen after 2 of 2 failures = false, want true")
+	}
+	if b.Allow() {
+		t.Fatalf("Allow while open = true, want false")
+	}
+}
+
+func TestCircuitBreaker_RecordSuccessCloses(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+	b.RecordFailure()
+	if !b.IsOpen() {
+		t.Fatalf("breaker should be open after 1 failure with threshold 1")
+	}
+	// Force it straight back to closed, bypassing the half-open cooldown,
+	// to check RecordSuccess resets state regardless of how it is reached.
+	b.mu.Lock()
+	b.state = breakerClosed
+	b.mu.Unlock()
+	b.RecordSuccess()
+	if b.IsOpen() {
+		t.Fatalf("IsOpen after RecordSuccess = true, want false")
+	}
+}
+
+// TestCircuitBreaker_HalfOpenAllowsOnlyOneProbe reproduces the bug where
+// every caller was let through once the cooldown elapsed: only the single
+// call that transitions the breaker from open to half-open should see
+// Allow return true, until RecordSuccess/RecordFailure resolves the probe.
+func TestCircuitBreaker_HalfOpenAllowsOnlyOneProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Millisecond)
+	b.RecordFailure()
+	if !b.IsOpen() {
+		t.Fatalf("breaker should be open after tripping")
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if b.Allow() {
+			allowed++
+		}
+	}
+	if allowed != 1 {
+		t.Fatalf("Allow returned true %d times while half-open, want exactly 1", allowed)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Millisecond)
+	b.RecordFailure()
+	time.Sleep(5 * time.Millisecond)
+	if !b.Allow() {
+		t.Fatalf("Allow should admit the half-open probe call")
+	}
+	b.RecordFailure()
+	if !b.IsOpen() {
+		t.Fatalf("breaker should reopen immediately when the probe call fails")
+	}
+}
@@ -0,0 +1,76 @@
+package avatar
+
+import "time"
+
+// SourceType identifies which ingestion path produced an AvatarJob.
+type SourceType string
+
+const (
+	// SourceHTTP marks jobs submitted through the synchronous SubmitJob call.
+	SourceHTTP SourceType = "http"
+	// SourceKafka marks jobs decoded from an inbound Kafka topic.
+	SourceKafka SourceType = "kafka"
+)
+
+// ModerationResponse represents the response from the content moderation API
+type ModerationResponse struct {
+	IsApproved bool   `json:"is_approved"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// AvatarJob represents an avatar generation job
+type AvatarJob struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	Status       string     `json:"status"` // pending, completed, failed, rejected, moderation_unavailable
+	InputData    string     `json:"input_data"`
+	OutputURL    *string    `json:"output_url,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+
+	// Source records which JobSource ingested this job, so status and
+	// error reporting can be traced back to its origin.
+	Source SourceType `json:"source"`
+	// SourceTopic is the Kafka topic the job was consumed from. Empty for
+	// jobs submitted over HTTP.
+	SourceTopic string `json:"source_topic,omitempty"`
+
+	// Tags are user-assigned labels managed through the tags API endpoint.
+	Tags []string `json:"tags,omitempty"`
+
+	// StorageACL is the ACL the avatar was stored with, recorded for
+	// auditing alongside OutputURL.
+	StorageACL string `json:"storage_acl,omitempty"`
+}
+
+// NewAvatarJob creates a new avatar job with default values
+func NewAvatarJob(id, userID, status, inputData string, source SourceType) *AvatarJob {
+	return &AvatarJob{
+		ID:        id,
+		UserID:    userID,
+		Status:    status,
+		InputData: inputData,
+		CreatedAt: time.Now(),
+		Source:    source,
+	}
+}
+
+// clone returns a deep copy of j, so a caller holding it can read or mutate
+// freely without racing a concurrent status/tag update on the original.
+// MemoryJobRepository returns clones from every method that would otherwise
+// hand out the pointer it stores in its map.
+func (j *AvatarJob) clone() *AvatarJob {
+	c := *j
+	if j.OutputURL != nil {
+		url := *j.OutputURL
+		c.OutputURL = &url
+	}
+	if j.ErrorMessage != nil {
+		msg := *j.ErrorMessage
+		c.ErrorMessage = &msg
+	}
+	if j.Tags != nil {
+		c.Tags = append([]string(nil), j.Tags...)
+	}
+	return &c
+}
@@ -0,0 +1,153 @@
+package avatar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// inboundEnvelope is the JSON shape read off the Kafka inbound topic.
+type inboundEnvelope struct {
+	UserID    string `json:"user_id"`
+	InputData string `json:"input_data"`
+}
+
+// KafkaJobSource consumes job submissions from a Kafka topic and decodes
+// them into AvatarJobs, following the same multi-source ingestion pattern
+// as the dmaap-mediator: any number of sources feed the same bounded queue.
+type KafkaJobSource struct {
+	reader *kafka.Reader
+	topic  string
+	newID  func() string
+	repo   JobRepository
+}
+
+// KafkaJobSourceConfig configures the Kafka consumer backing a
+// KafkaJobSource.
+type KafkaJobSourceConfig struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+	// NewJobID generates the ID assigned to decoded jobs. Defaults to a
+	// topic+offset derived ID if nil.
+	NewJobID func() string
+	// Repo persists each decoded job before it is enqueued, same as the
+	// HTTP path's SubmitJob, so UpdateStatus has a row to transition once
+	// the job finishes processing.
+	Repo JobRepository
+}
+
+// NewKafkaJobSource creates a KafkaJobSource from the given configuration.
+func NewKafkaJobSource(cfg KafkaJobSourceConfig) *KafkaJobSource {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: cfg.Brokers,
+		Topic:   cfg.Topic,
+		GroupID: cfg.GroupID,
+	})
+	return &KafkaJobSource{reader: reader, topic: cfg.Topic, newID: cfg.NewJobID, repo: cfg.Repo}
+}
+
+// Name implements JobSource.
+func (s *KafkaJobSource) Name() SourceType { return SourceKafka }
+
+// Run reads messages from the configured topic until ctx is cancelled,
+// decoding each into an AvatarJob and enqueuing it. Malformed messages are
+// logged and skipped rather than aborting the consumer.
+func (s *KafkaJobSource) Run(ctx context.Context, queue *JobQueue) error {
+	for {
+		msg, err := s.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("avatar: kafka fetch message: %w", err)
+		}
+
+		var env inboundEnvelope
+		if err := json.Unmarshal(msg.Value, &env); err != nil {
+			log.Printf("avatar: discarding malformed kafka job envelope at offset %d: %v", msg.Offset, err)
+			if cerr := s.reader.CommitMessages(ctx, msg); cerr != nil {
+				log.Printf("avatar: commit malformed message: %v", cerr)
+			}
+			continue
+		}
+
+		id := s.jobID(msg)
+		job := NewAvatarJob(id, env.UserID, "pending", env.InputData, SourceKafka)
+		job.SourceTopic = s.topic
+
+		if err := s.repo.Save(ctx, job); err != nil {
+			log.Printf("avatar: dropping kafka job %s, save failed: %v", id, err)
+			if cerr := s.reader.CommitMessages(ctx, msg); cerr != nil {
+				log.Printf("avatar: commit message offset %d: %v", msg.Offset, cerr)
+			}
+			continue
+		}
+
+		if err := queue.Enqueue(job); err != nil {
+			log.Printf("avatar: dropping kafka job %s: %v", id, err)
+			continue
+		}
+
+		if err := s.reader.CommitMessages(ctx, msg); err != nil {
+			log.Printf("avatar: commit message offset %d: %v", msg.Offset, err)
+		}
+	}
+}
+
+func (s *KafkaJobSource) jobID(msg kafka.Message) string {
+	if s.newID != nil {
+		return s.newID()
+	}
+	return fmt.Sprintf("%s-%d-%d", s.topic, msg.Partition, msg.Offset)
+}
+
+// Stop closes the underlying Kafka reader.
+func (s *KafkaJobSource) Stop() error {
+	return s.reader.Close()
+}
+
+// KafkaOutboundPublisher publishes terminal job status changes to an
+// outbound Kafka topic as JSON messages keyed by job ID. It does not modify
+// the job it publishes.
+type KafkaOutboundPublisher struct {
+	writer *kafka.Writer
+	topic  string
+}
+
+// NewKafkaOutboundPublisher creates a KafkaOutboundPublisher writing to the
+// given topic.
+func NewKafkaOutboundPublisher(brokers []string, topic string) *KafkaOutboundPublisher {
+	return &KafkaOutboundPublisher{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.LeastBytes{},
+		},
+		topic: topic,
+	}
+}
+
+// Publish implements OutboundPublisher.
+func (p *KafkaOutboundPublisher) Publish(ctx context.Context, job *AvatarJob) error {
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("avatar: marshal outbound job %s: %w", job.ID, err)
+	}
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(job.ID),
+		Value: payload,
+	})
+	if err != nil {
+		return fmt.Errorf("avatar: publish job %s to topic %s: %w", job.ID, p.topic, err)
+	}
+	return nil
+}
+
+// Close closes the underlying Kafka writer.
+func (p *KafkaOutboundPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,58 @@
+package avatar
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"backend-interview-challenge/avatar/migrations"
+)
+
+// runMigrations applies every pending up migration for dialect against db,
+// using the embedded SQL files under avatar/migrations. It is called once
+// at service startup by NewSQLiteJobRepository / NewPostgresJobRepository
+// so the schema is always current before the repository serves traffic.
+func runMigrations(db *sql.DB, dialect string) error {
+	var (
+		source fs.FS
+		dir    string
+		driver database.Driver
+		err    error
+	)
+
+	switch dialect {
+	case "sqlite3":
+		source, dir = migrations.SQLite, "sqlite"
+		driver, err = sqlite3.WithInstance(db, &sqlite3.Config{})
+	case "postgres":
+		source, dir = migrations.Postgres, "postgres"
+		driver, err = postgres.WithInstance(db, &postgres.Config{})
+	default:
+		return fmt.Errorf("avatar: unsupported migration dialect %q", dialect)
+	}
+	if err != nil {
+		return fmt.Errorf("avatar: init %s migration driver: %w", dialect, err)
+	}
+
+	sourceDriver, err := iofs.New(source, dir)
+	if err != nil {
+		return fmt.Errorf("avatar: init migration source for %s: %w", dialect, err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, dialect, driver)
+	if err != nil {
+		return fmt.Errorf("avatar: init migrator for %s: %w", dialect, err)
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("avatar: run %s migrations: %w", dialect, err)
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+// Package migrations embeds the SQL migration files for every supported
+// JobRepository SQL backend, so the migration runner needs no access to the
+// filesystem at deploy time.
+package migrations
+
+import "embed"
+
+//go:embed sqlite/*.sql
+var SQLite embed.FS
+
+//go:embed postgres/*.sql
+var Postgres embed.FS
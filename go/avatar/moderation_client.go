@@ -0,0 +1,146 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// moderationRequest is the JSON body sent to the moderation API.
+type moderationRequest struct {
+	Content string `json:"content"`
+	UserID  string `json:"user_id"`
+}
+
+// moderationAPIResponse is the JSON body returned by the moderation API.
+type moderationAPIResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// CallModerationAPI calls the content moderation API to check if avatar
+// passes guidelines. It retries network errors, 429s, and 5xx responses
+// with exponential backoff and jitter (honoring Retry-After when present),
+// and short-circuits through a circuit breaker when the API is unhealthy so
+// callers fail fast with ErrCodeModerationUnavailable instead of timing out.
+//
+// Args:
+//
+//	content: Description of the generated avatar
+//	userID: ID of the user who requested the avatar
+//
+// Returns:
+//
+//	*ModerationResponse: Moderation result
+//	error: A *ModerationError classifying the failure, if any
+func (s *AvatarProcessingService) CallModerationAPI(content, userID string) (*ModerationResponse, error) {
+	if !s.breaker.Allow() {
+		return nil, ErrModerationUnavailable("moderation API circuit breaker is open", 0)
+	}
+
+	reqBody, err := json.Marshal(moderationRequest{Content: content, UserID: userID})
+	if err != nil {
+		return nil, ErrModerationInvalidResponse("encode moderation request: "+err.Error(), 0)
+	}
+
+	deadline := time.Now().Add(s.retryPolicy.Budget)
+	var lastErr *ModerationError
+	attempts := 0
+
+	for attempt := 1; attempt <= s.retryPolicy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(s.retryPolicy.backoff(attempt - 1))
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		if attempt > 1 {
+			moderationRetries.Inc()
+		}
+		attempts = attempt
+
+		resp, callErr := s.attemptModerationCall(reqBody)
+		if callErr == nil {
+			s.breaker.RecordSuccess()
+			return resp, nil
+		}
+		lastErr = callErr
+
+		if !callErr.retryable() {
+			// Not retryable: a malformed response or an outright request
+			// rejection won't improve on a second attempt.
+			s.breaker.RecordFailure()
+			if s.breaker.IsOpen() {
+				moderationBreakerTrips.Inc()
+			}
+			lastErr.Attempts = attempt
+			return nil, lastErr
+		}
+	}
+
+	s.breaker.RecordFailure()
+	if s.breaker.IsOpen() {
+		moderationBreakerTrips.Inc()
+	}
+	if lastErr == nil {
+		lastErr = ErrModerationUnavailable("moderation API retry budget exhausted", attempts)
+	} else {
+		lastErr.Attempts = attempts
+	}
+	return nil, lastErr
+}
+
+// attemptModerationCall performs a single HTTP round trip against the
+// moderation API, classifying the outcome into a *ModerationError on
+// failure.
+func (s *AvatarProcessingService) attemptModerationCall(reqBody []byte) (*ModerationResponse, *ModerationError) {
+	moderationAttempts.Inc()
+	start := time.Now()
+
+	req, err := http.NewRequest(http.MethodPost, s.moderationAPIURL+"/api/v1/moderate-content", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, ErrModerationInvalidResponse("build moderation request: "+err.Error(), 1)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiToken)
+
+	resp, err := s.httpClient.Do(req)
+	moderationLatency.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, ErrModerationTimeout("call moderation API: "+err.Error(), 1)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if retryableStatus(resp.StatusCode) {
+			if wait := retryAfter(resp.Header.Get("Retry-After")); wait > 0 {
+				time.Sleep(wait)
+			}
+			return nil, &ModerationError{Code: ErrCodeModerationUnavailable, Message: "moderation API returned status " + strconv.Itoa(resp.StatusCode)}
+		}
+		return nil, ErrModerationRejected("moderation API rejected request with status "+strconv.Itoa(resp.StatusCode), 1)
+	}
+
+	var result moderationAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, ErrModerationInvalidResponse("decode moderation response: "+err.Error(), 1)
+	}
+
+	return &ModerationResponse{IsApproved: result.Approved, Reason: result.Reason}, nil
+}
+
+// retryAfter parses a Retry-After header value expressed in seconds. It
+// returns 0 if the header is absent or not a valid integer (HTTP-date
+// values are not honored).
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,140 @@
+package avatar
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestModerationService builds an AvatarProcessingService pointed at url
+// with a fast retry policy, so tests don't pay DefaultRetryPolicy's real
+// backoff delays. CallModerationAPI only touches moderationAPIURL,
+// apiToken, httpClient, breaker and retryPolicy, so storage/repo/outbound
+// are left nil.
+func newTestModerationService(url string) *AvatarProcessingService {
+	s := NewAvatarProcessingService(url, "test-token", 1, 1, nil, nil, nil)
+	s.retryPolicy = RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     1,
+		Jitter:         0,
+		Budget:         time.Second,
+	}
+	s.breaker = NewCircuitBreaker(100, time.Minute)
+	return s
+}
+
+func TestCallModerationAPI_Approved(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"approved": true, "reason": ""}`))
+	}))
+	defer srv.Close()
+
+	resp, err := newTestModerationService(srv.URL).CallModerationAPI("a robot avatar", "user-1")
+	if err != nil {
+		t.Fatalf("CallModerationAPI: %v", err)
+	}
+	if !resp.IsApproved {
+		t.Fatalf("IsApproved = false, want true")
+	}
+}
+
+func TestCallModerationAPI_RejectedIsNotRetried(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	_, err := newTestModerationService(srv.URL).CallModerationAPI("bad content", "user-1")
+	if err == nil {
+		t.Fatalf("CallModerationAPI with a 400 response should error")
+	}
+	modErr, ok := err.(*ModerationError)
+	if !ok {
+		t.Fatalf("error type = %T, want *ModerationError", err)
+	}
+	if modErr.Code != ErrCodeModerationRejected {
+		t.Fatalf("Code = %q, want %q", modErr.Code, ErrCodeModerationRejected)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1 (a 400 should not be retried)", attempts)
+	}
+}
+
+func TestCallModerationAPI_RetriesServerErrorsThenSucceeds(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"approved": true, "reason": ""}`))
+	}))
+	defer srv.Close()
+
+	resp, err := newTestModerationService(srv.URL).CallModerationAPI("a robot avatar", "user-1")
+	if err != nil {
+		t.Fatalf("CallModerationAPI: %v", err)
+	}
+	if !resp.IsApproved {
+		t.Fatalf("IsApproved = false, want true")
+	}
+	if attempts != 2 {
+		t.Fatalf("attempts = %d, want 2 (one 503 then a success)", attempts)
+	}
+}
+
+func TestCallModerationAPI_RetryBudgetExhausted(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := newTestModerationService(srv.URL).CallModerationAPI("a robot avatar", "user-1")
+	if err == nil {
+		t.Fatalf("CallModerationAPI should error once every attempt returns 503")
+	}
+	modErr, ok := err.(*ModerationError)
+	if !ok {
+		t.Fatalf("error type = %T, want *ModerationError", err)
+	}
+	if modErr.Code != ErrCodeModerationUnavailable {
+		t.Fatalf("Code = %q, want %q", modErr.Code, ErrCodeModerationUnavailable)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3 (MaxAttempts)", attempts)
+	}
+}
+
+func TestCallModerationAPI_CircuitBreakerOpenFailsFast(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	s := newTestModerationService(srv.URL)
+	s.breaker = NewCircuitBreaker(1, time.Minute)
+
+	if _, err := s.CallModerationAPI("content", "user-1"); err == nil {
+		t.Fatalf("expected the first call to exhaust the retry budget and fail")
+	}
+	firstAttempts := attempts
+
+	if _, err := s.CallModerationAPI("content", "user-1"); err == nil {
+		t.Fatalf("expected the breaker to be open on the second call")
+	} else if modErr, ok := err.(*ModerationError); !ok || modErr.Code != ErrCodeModerationUnavailable {
+		t.Fatalf("error = %v, want an ErrCodeModerationUnavailable ModerationError", err)
+	}
+	if attempts != firstAttempts {
+		t.Fatalf("a second call with the breaker open should not reach the server, attempts went from %d to %d", firstAttempts, attempts)
+	}
+}
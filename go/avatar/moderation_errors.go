@@ -0,0 +1,77 @@
+package avatar
+
+import "encoding/json"
+
+// ModerationErrorCode classifies why a call to the moderation API failed,
+// mirroring harbor's scan job error model so callers can branch on the
+// failure mode instead of parsing error strings.
+type ModerationErrorCode string
+
+const (
+	// ErrCodeModerationTimeout means the last attempt against the
+	// moderation API failed with a network error or timeout.
+	ErrCodeModerationTimeout ModerationErrorCode = "moderation_timeout"
+	// ErrCodeModerationRejected means the moderation API rejected the
+	// request itself (a 4xx other than 429), as opposed to disapproving
+	// the avatar's content.
+	ErrCodeModerationRejected ModerationErrorCode = "moderation_rejected"
+	// ErrCodeModerationInvalidResponse means the moderation API returned a
+	// response that could not be decoded into ModerationResponse.
+	ErrCodeModerationInvalidResponse ModerationErrorCode = "moderation_invalid_response"
+	// ErrCodeModerationUnavailable means the circuit breaker was open or
+	// the retry budget was exhausted against repeated 429/5xx responses.
+	ErrCodeModerationUnavailable ModerationErrorCode = "moderation_unavailable"
+)
+
+// ModerationError is the structured error CallModerationAPI returns on
+// failure. It is JSON-marshaled onto AvatarJob.ErrorMessage as a
+// {code, message, attempts} blob so API consumers can classify failures
+// without string matching.
+type ModerationError struct {
+	Code     ModerationErrorCode `json:"code"`
+	Message  string              `json:"message"`
+	Attempts int                 `json:"attempts"`
+}
+
+func (e *ModerationError) Error() string {
+	return e.Message
+}
+
+// retryable reports whether CallModerationAPI should attempt the call
+// again: transient network/timeout failures and provisional 429/5xx
+// responses, as opposed to a malformed response or an outright rejection
+// that won't improve on a retry.
+func (e *ModerationError) retryable() bool {
+	return e.Code == ErrCodeModerationTimeout || e.Code == ErrCodeModerationUnavailable
+}
+
+// JSON renders the error as the structured blob stored on
+// AvatarJob.ErrorMessage. It never fails: encoding a ModerationError into
+// its own declared fields cannot produce an unsupported type.
+func (e *ModerationError) JSON() string {
+	data, _ := json.Marshal(e)
+	return string(data)
+}
+
+// ErrModerationTimeout builds a ModerationError for exhausted timeouts.
+func ErrModerationTimeout(message string, attempts int) *ModerationError {
+	return &ModerationError{Code: ErrCodeModerationTimeout, Message: message, Attempts: attempts}
+}
+
+// ErrModerationRejected builds a ModerationError for a request the
+// moderation API itself refused to process.
+func ErrModerationRejected(message string, attempts int) *ModerationError {
+	return &ModerationError{Code: ErrCodeModerationRejected, Message: message, Attempts: attempts}
+}
+
+// ErrModerationInvalidResponse builds a ModerationError for a response body
+// that could not be parsed.
+func ErrModerationInvalidResponse(message string, attempts int) *ModerationError {
+	return &ModerationError{Code: ErrCodeModerationInvalidResponse, Message: message, Attempts: attempts}
+}
+
+// ErrModerationUnavailable builds a ModerationError for a breaker-open or
+// retry-budget-exhausted failure.
+func ErrModerationUnavailable(message string, attempts int) *ModerationError {
+	return &ModerationError{Code: ErrCodeModerationUnavailable, Message: message, Attempts: attempts}
+}
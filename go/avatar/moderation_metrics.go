@@ -0,0 +1,27 @@
+package avatar
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	moderationAttempts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "avatar_moderation_attempts_total",
+		Help: "Total number of HTTP attempts made against the moderation API, including retries.",
+	})
+	moderationRetries = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "avatar_moderation_retries_total",
+		Help: "Total number of retry attempts against the moderation API.",
+	})
+	moderationBreakerTrips = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "avatar_moderation_breaker_trips_total",
+		Help: "Total number of times the moderation API circuit breaker tripped open.",
+	})
+	moderationLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "avatar_moderation_call_latency_seconds",
+		Help:    "Latency of individual HTTP calls to the moderation API.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	prometheus.MustRegister(moderationAttempts, moderationRetries, moderationBreakerTrips, moderationLatency)
+}
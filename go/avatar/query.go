@@ -0,0 +1,50 @@
+package avatar
+
+import (
+	"context"
+	"time"
+)
+
+// ListJobs returns jobs matching filter, ordered by CreatedAt descending,
+// along with the total count of matches before pagination was applied.
+func (s *AvatarProcessingService) ListJobs(filter JobFilter) ([]*AvatarJob, int, error) {
+	return s.repo.List(context.Background(), filter)
+}
+
+// UpdateTags adds and removes tags on the job identified by jobID.
+func (s *AvatarProcessingService) UpdateTags(jobID string, add, remove []string) (*AvatarJob, error) {
+	return s.repo.UpdateTags(context.Background(), jobID, add, remove)
+}
+
+// JobMetrics summarizes job counts by status and hourly throughput of job
+// creation, for the metrics API endpoint.
+type JobMetrics struct {
+	CountByStatus map[string]int `json:"count_by_status"`
+	// ThroughputByHour maps an hour bucket, formatted as RFC3339 truncated
+	// to the hour, to the number of jobs created in that hour.
+	ThroughputByHour map[string]int `json:"throughput_by_hour"`
+}
+
+// Metrics computes job counts grouped by status and per-hour throughput
+// across all known jobs.
+func (s *AvatarProcessingService) Metrics() (JobMetrics, error) {
+	ctx := context.Background()
+
+	countByStatus, err := s.repo.CountByStatus(ctx)
+	if err != nil {
+		return JobMetrics{}, err
+	}
+
+	jobs, _, err := s.repo.List(ctx, JobFilter{})
+	if err != nil {
+		return JobMetrics{}, err
+	}
+
+	throughput := make(map[string]int, len(jobs))
+	for _, job := range jobs {
+		bucket := job.CreatedAt.UTC().Truncate(time.Hour).Format(time.RFC3339)
+		throughput[bucket]++
+	}
+
+	return JobMetrics{CountByStatus: countByStatus, ThroughputByHour: throughput}, nil
+}
@@ -0,0 +1,131 @@
+package avatar
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// ErrQueueFull is returned by JobQueue.Enqueue when the bounded channel has
+// no room left and the caller should apply backpressure.
+var ErrQueueFull = errors.New("avatar: job queue is full")
+
+// ErrQueueClosed is returned by JobQueue.Enqueue once Shutdown has been
+// called; the caller should stop submitting new jobs.
+var ErrQueueClosed = errors.New("avatar: job queue is closed")
+
+// OutboundPublisher publishes terminal job status changes (completed,
+// rejected, failed) to an external system once a worker finishes processing
+// a job. KafkaOutboundPublisher is the production implementation; tests can
+// supply a stub.
+type OutboundPublisher interface {
+	Publish(ctx context.Context, job *AvatarJob) error
+}
+
+// noopOutboundPublisher discards terminal status events. It is the default
+// when no outbound Kafka topic is configured.
+type noopOutboundPublisher struct{}
+
+func (noopOutboundPublisher) Publish(context.Context, *AvatarJob) error { return nil }
+
+// JobQueue is a bounded, worker-pool backed queue of AvatarJobs. Jobs are
+// pushed onto it by one or more JobSources and drained by a fixed pool of
+// workers that run processFn against each job.
+type JobQueue struct {
+	jobs      chan *AvatarJob
+	workers   int
+	processFn func(context.Context, *AvatarJob)
+
+	mu       sync.Mutex
+	closed   bool
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+	stopped  chan struct{}
+}
+
+// NewJobQueue creates a JobQueue with the given channel capacity and worker
+// pool size. processFn is invoked once per dequeued job by whichever worker
+// picks it up, and must be safe for concurrent use.
+func NewJobQueue(capacity, workers int, processFn func(context.Context, *AvatarJob)) *JobQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	return &JobQueue{
+		jobs:      make(chan *AvatarJob, capacity),
+		workers:   workers,
+		processFn: processFn,
+		stopped:   make(chan struct{}),
+	}
+}
+
+// Start launches the worker pool. It returns immediately; workers run until
+// ctx is cancelled or Shutdown is called.
+func (q *JobQueue) Start(ctx context.Context) {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.runWorker(ctx)
+	}
+}
+
+func (q *JobQueue) runWorker(ctx context.Context) {
+	defer q.wg.Done()
+	for {
+		select {
+		case job, ok := <-q.jobs:
+			if !ok {
+				return
+			}
+			q.processFn(ctx, job)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Enqueue pushes a job onto the queue without blocking. It returns
+// ErrQueueFull if the bounded channel is at capacity, or ErrQueueClosed if
+// Shutdown has already been called. The send happens under the same lock
+// Shutdown takes to close the channel, so a job fetched by a JobSource just
+// as shutdown begins never races a send on a closed channel.
+func (q *JobQueue) Enqueue(job *AvatarJob) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting new jobs and waits for in-flight jobs to finish
+// processing, or for ctx to be cancelled, whichever comes first.
+func (q *JobQueue) Shutdown(ctx context.Context) error {
+	q.stopOnce.Do(func() {
+		q.mu.Lock()
+		q.closed = true
+		close(q.jobs)
+		q.mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		close(q.stopped)
+		return nil
+	case <-ctx.Done():
+		log.Printf("avatar: queue shutdown timed out with jobs still draining")
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,103 @@
+package avatar
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJobQueue_ProcessesEnqueuedJobs(t *testing.T) {
+	var processed int32
+	done := make(chan struct{}, 1)
+
+	q := NewJobQueue(4, 2, func(_ context.Context, job *AvatarJob) {
+		if atomic.AddInt32(&processed, 1) == 3 {
+			done <- struct{}{}
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q.Start(ctx)
+
+	for i := 0; i < 3; i++ {
+		if err := q.Enqueue(NewAvatarJob("job", "user", "pending", "", SourceHTTP)); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("processed only %d of 3 jobs before timing out", atomic.LoadInt32(&processed))
+	}
+}
+
+func TestJobQueue_EnqueueFullReturnsErrQueueFull(t *testing.T) {
+	block := make(chan struct{})
+	q := NewJobQueue(1, 1, func(_ context.Context, _ *AvatarJob) { <-block })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q.Start(ctx)
+
+	// job-1 is picked up by the sole worker and blocks there, freeing the
+	// channel buffer; job-2 then fills the one buffered slot, so job-3 has
+	// nowhere to go.
+	if err := q.Enqueue(NewAvatarJob("job-1", "user", "pending", "", SourceHTTP)); err != nil {
+		t.Fatalf("Enqueue job-1: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err := q.Enqueue(NewAvatarJob("job-2", "user", "pending", "", SourceHTTP)); err != nil {
+		t.Fatalf("Enqueue job-2: %v", err)
+	}
+	if err := q.Enqueue(NewAvatarJob("job-3", "user", "pending", "", SourceHTTP)); err != ErrQueueFull {
+		t.Fatalf("Enqueue on a full queue = %v, want ErrQueueFull", err)
+	}
+
+	close(block)
+}
+
+func TestJobQueue_ShutdownThenEnqueueReturnsErrQueueClosed(t *testing.T) {
+	q := NewJobQueue(4, 1, func(_ context.Context, _ *AvatarJob) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q.Start(ctx)
+
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if err := q.Enqueue(NewAvatarJob("job", "user", "pending", "", SourceHTTP)); err != ErrQueueClosed {
+		t.Fatalf("Enqueue after Shutdown = %v, want ErrQueueClosed", err)
+	}
+}
+
+// TestJobQueue_ConcurrentEnqueueDuringShutdown reproduces the
+// send-on-closed-channel panic a JobSource could trigger by enqueueing a
+// job concurrently with Shutdown: Enqueue must never panic, only return
+// ErrQueueClosed once Shutdown has taken effect.
+func TestJobQueue_ConcurrentEnqueueDuringShutdown(t *testing.T) {
+	q := NewJobQueue(4, 2, func(_ context.Context, _ *AvatarJob) {})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q.Start(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = q.Enqueue(NewAvatarJob("job", "user", "pending", "", SourceHTTP))
+		}()
+	}
+
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,65 @@
+package avatar
+
+import (
+	"context"
+	"time"
+)
+
+// JobFilter narrows JobRepository.List results by user, status, and
+// creation date range. Zero-value fields are treated as "no filter" for
+// that dimension.
+type JobFilter struct {
+	UserID string
+	Status string
+	From   time.Time
+	To     time.Time
+
+	// Offset and Limit page through the filtered result set. Limit <= 0
+	// means "no limit".
+	Offset int
+	Limit  int
+
+	// After and AfterID, when AfterID is non-empty, request keyset
+	// pagination instead of Offset: only jobs created strictly before
+	// (After, AfterID) in the CreatedAt-descending, ID-descending order are
+	// returned. SQL-backed repositories use this to avoid the cost of
+	// OFFSET on large tables; Offset is ignored when AfterID is set.
+	After   time.Time
+	AfterID string
+}
+
+// JobRepository persists AvatarJobs and answers the queries the service and
+// REST API need: lookup by ID, filtered/paginated listing, status
+// transitions, deletion, and per-status counts. MemoryJobRepository,
+// SQLiteJobRepository, and PostgresJobRepository all implement it against
+// the same contract, exercised by the shared test suite in
+// repository_test.go.
+type JobRepository interface {
+	// Save persists a newly created job. Callers must not call Save twice
+	// for the same job ID; use UpdateStatus for transitions.
+	Save(ctx context.Context, job *AvatarJob) error
+
+	// Get returns the job with the given ID, or (nil, nil) if it does not
+	// exist.
+	Get(ctx context.Context, id string) (*AvatarJob, error)
+
+	// List returns jobs matching filter, ordered by CreatedAt descending,
+	// along with the total count of matches before pagination was applied.
+	List(ctx context.Context, filter JobFilter) ([]*AvatarJob, int, error)
+
+	// UpdateStatus atomically transitions a job's status and its
+	// associated output fields, returning the updated job. Implementations
+	// must ensure this is safe to call concurrently with other transitions
+	// on different jobs, and idempotent-safe across a worker restart
+	// mid-transition.
+	UpdateStatus(ctx context.Context, id string, status string, outputURL *string, storageACL string, errMsg *string) (*AvatarJob, error)
+
+	// UpdateTags replaces a job's tag set after applying add and remove.
+	UpdateTags(ctx context.Context, id string, add, remove []string) (*AvatarJob, error)
+
+	// Delete removes a job. It is a no-op if the job does not exist.
+	Delete(ctx context.Context, id string) error
+
+	// CountByStatus returns the number of jobs in each status.
+	CountByStatus(ctx context.Context) (map[string]int, error)
+}
@@ -0,0 +1,170 @@
+package avatar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryJobRepository is the original in-memory JobRepository
+// implementation: a mutex-guarded map. It is the default for local
+// development and tests; SQLiteJobRepository and PostgresJobRepository
+// provide durable alternatives. Every method that would otherwise hand out
+// a pointer stored in the map returns AvatarJob.clone() instead, so a
+// caller reading a returned job never races a concurrent UpdateStatus or
+// UpdateTags call on the original.
+type MemoryJobRepository struct {
+	mu   sync.RWMutex
+	jobs map[string]*AvatarJob
+}
+
+// NewMemoryJobRepository creates an empty MemoryJobRepository.
+func NewMemoryJobRepository() *MemoryJobRepository {
+	return &MemoryJobRepository{jobs: make(map[string]*AvatarJob)}
+}
+
+// Save implements JobRepository.
+func (r *MemoryJobRepository) Save(_ context.Context, job *AvatarJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = job
+	return nil
+}
+
+// Get implements JobRepository.
+func (r *MemoryJobRepository) Get(_ context.Context, id string) (*AvatarJob, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return job.clone(), nil
+}
+
+// List implements JobRepository.
+func (r *MemoryJobRepository) List(_ context.Context, filter JobFilter) ([]*AvatarJob, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*AvatarJob, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		if filter.UserID != "" && job.UserID != filter.UserID {
+			continue
+		}
+		if filter.Status != "" && job.Status != filter.Status {
+			continue
+		}
+		if !filter.From.IsZero() && job.CreatedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && job.CreatedAt.After(filter.To) {
+			continue
+		}
+		matched = append(matched, job.clone())
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].ID > matched[j].ID
+		}
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	total := len(matched)
+
+	if filter.AfterID != "" {
+		start := len(matched)
+		for i, job := range matched {
+			if job.CreatedAt.Before(filter.After) || (job.CreatedAt.Equal(filter.After) && job.ID < filter.AfterID) {
+				start = i
+				break
+			}
+		}
+		matched = matched[start:]
+	} else if filter.Offset > 0 {
+		if filter.Offset >= total {
+			return []*AvatarJob{}, total, nil
+		}
+		matched = matched[filter.Offset:]
+	}
+
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total, nil
+}
+
+// UpdateStatus implements JobRepository.
+func (r *MemoryJobRepository) UpdateStatus(_ context.Context, id string, status string, outputURL *string, storageACL string, errMsg *string) (*AvatarJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("avatar: job %s not found", id)
+	}
+	job.Status = status
+	job.OutputURL = outputURL
+	job.StorageACL = storageACL
+	job.ErrorMessage = errMsg
+	return job.clone(), nil
+}
+
+// UpdateTags implements JobRepository.
+func (r *MemoryJobRepository) UpdateTags(_ context.Context, id string, add, remove []string) (*AvatarJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("avatar: job %s not found", id)
+	}
+
+	removeSet := make(map[string]bool, len(remove))
+	for _, t := range remove {
+		removeSet[t] = true
+	}
+
+	kept := job.Tags[:0]
+	for _, t := range job.Tags {
+		if !removeSet[t] {
+			kept = append(kept, t)
+		}
+	}
+	job.Tags = kept
+
+	existing := make(map[string]bool, len(job.Tags))
+	for _, t := range job.Tags {
+		existing[t] = true
+	}
+	for _, t := range add {
+		if !existing[t] {
+			job.Tags = append(job.Tags, t)
+			existing[t] = true
+		}
+	}
+
+	return job.clone(), nil
+}
+
+// Delete implements JobRepository.
+func (r *MemoryJobRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, id)
+	return nil
+}
+
+// CountByStatus implements JobRepository.
+func (r *MemoryJobRepository) CountByStatus(_ context.Context) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, job := range r.jobs {
+		counts[job.Status]++
+	}
+	return counts, nil
+}
@@ -0,0 +1,43 @@
+package avatar
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PostgresJobRepository is a JobRepository backed by Postgres.
+type PostgresJobRepository struct {
+	*sqlJobRepository
+	db *sql.DB
+}
+
+// NewPostgresJobRepository opens dsn (a libpq connection string or URL),
+// configures the connection pool, and runs pending migrations before
+// returning.
+func NewPostgresJobRepository(dsn string) (*PostgresJobRepository, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("avatar: open postgres database: %w", err)
+	}
+	db.SetMaxOpenConns(20)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(30 * time.Minute)
+
+	if err := runMigrations(db, "postgres"); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &PostgresJobRepository{
+		sqlJobRepository: &sqlJobRepository{db: db, placeholder: func(n int) string { return fmt.Sprintf("$%d", n) }},
+		db:               db,
+	}, nil
+}
+
+// Close closes the underlying database connection pool.
+func (r *PostgresJobRepository) Close() error {
+	return r.db.Close()
+}
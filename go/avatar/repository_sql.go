@@ -0,0 +1,279 @@
+package avatar
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sqlJobRepository implements JobRepository against a database/sql handle.
+// SQLiteJobRepository and PostgresJobRepository both embed it, differing
+// only in driver, DSN, and placeholder syntax (? vs $1), so the query logic
+// lives here once.
+type sqlJobRepository struct {
+	db *sql.DB
+	// placeholder returns the bind parameter syntax for the n-th argument
+	// (1-indexed), e.g. "?" for SQLite or fmt.Sprintf("$%d", n) for
+	// Postgres.
+	placeholder func(n int) string
+}
+
+func (r *sqlJobRepository) ph(n int) string { return r.placeholder(n) }
+
+// Save implements JobRepository.
+func (r *sqlJobRepository) Save(ctx context.Context, job *AvatarJob) error {
+	query := fmt.Sprintf(`
+		INSERT INTO avatar_jobs (id, user_id, status, input_data, output_url, storage_acl, created_at, error_message, source, source_topic, tags)
+		VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`,
+		r.ph(1), r.ph(2), r.ph(3), r.ph(4), r.ph(5), r.ph(6), r.ph(7), r.ph(8), r.ph(9), r.ph(10), r.ph(11))
+
+	_, err := r.db.ExecContext(ctx, query,
+		job.ID, job.UserID, job.Status, job.InputData, job.OutputURL, job.StorageACL,
+		job.CreatedAt, job.ErrorMessage, string(job.Source), job.SourceTopic, joinTags(job.Tags))
+	if err != nil {
+		return fmt.Errorf("avatar: save job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// Get implements JobRepository.
+func (r *sqlJobRepository) Get(ctx context.Context, id string) (*AvatarJob, error) {
+	query := fmt.Sprintf(`
+		SELECT id, user_id, status, input_data, output_url, storage_acl, created_at, error_message, source, source_topic, tags
+		FROM avatar_jobs WHERE id = %s`, r.ph(1))
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("avatar: get job %s: %w", id, err)
+	}
+	return job, nil
+}
+
+// List implements JobRepository.
+func (r *sqlJobRepository) List(ctx context.Context, filter JobFilter) ([]*AvatarJob, int, error) {
+	where, args := r.whereClause(filter)
+
+	// The total ignores the keyset cursor, like Offset and Limit.
+	countFilter := filter
+	countFilter.AfterID = ""
+	countWhere, countArgs := r.whereClause(countFilter)
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM avatar_jobs" + countWhere
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("avatar: count jobs: %w", err)
+	}
+
+	query := `SELECT id, user_id, status, input_data, output_url, storage_acl, created_at, error_message, source, source_topic, tags
+		FROM avatar_jobs` + where + " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %s", r.ph(len(args)+1))
+		args = append(args, filter.Limit)
+	}
+	if filter.AfterID == "" && filter.Offset > 0 {
+		query += fmt.Sprintf(" OFFSET %s", r.ph(len(args)+1))
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("avatar: list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*AvatarJob
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("avatar: scan job row: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, total, rows.Err()
+}
+
+// whereClause builds the WHERE clause and bind args for List's count and
+// select queries, including the keyset predicate when AfterID is set.
+func (r *sqlJobRepository) whereClause(filter JobFilter) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	add := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		clauses = append(clauses, fmt.Sprintf(clause, r.ph(len(args))))
+	}
+
+	if filter.UserID != "" {
+		add("user_id = %s", filter.UserID)
+	}
+	if filter.Status != "" {
+		add("status = %s", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		add("created_at >= %s", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("created_at <= %s", filter.To)
+	}
+	if filter.AfterID != "" {
+		args = append(args, filter.After, filter.AfterID)
+		clauses = append(clauses, fmt.Sprintf("(created_at < %s OR (created_at = %s AND id < %s))",
+			r.ph(len(args)-1), r.ph(len(args)-1), r.ph(len(args))))
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// UpdateStatus implements JobRepository. It runs inside a transaction so a
+// concurrent read never observes a job mid-update, and so a worker crash
+// between the row lookup and the write leaves the prior status intact
+// rather than a partial one.
+func (r *sqlJobRepository) UpdateStatus(ctx context.Context, id string, status string, outputURL *string, storageACL string, errMsg *string) (*AvatarJob, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("avatar: begin update status tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf(`UPDATE avatar_jobs SET status = %s, output_url = %s, storage_acl = %s, error_message = %s WHERE id = %s`,
+		r.ph(1), r.ph(2), r.ph(3), r.ph(4), r.ph(5))
+	res, err := tx.ExecContext(ctx, query, status, outputURL, storageACL, errMsg, id)
+	if err != nil {
+		return nil, fmt.Errorf("avatar: update status for job %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, fmt.Errorf("avatar: job %s not found", id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("avatar: commit status update for job %s: %w", id, err)
+	}
+
+	return r.Get(ctx, id)
+}
+
+// UpdateTags implements JobRepository.
+func (r *sqlJobRepository) UpdateTags(ctx context.Context, id string, add, remove []string) (*AvatarJob, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("avatar: begin update tags tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var tagsCSV string
+	selectQuery := fmt.Sprintf("SELECT tags FROM avatar_jobs WHERE id = %s", r.ph(1))
+	if err := tx.QueryRowContext(ctx, selectQuery, id).Scan(&tagsCSV); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("avatar: job %s not found", id)
+		}
+		return nil, fmt.Errorf("avatar: read tags for job %s: %w", id, err)
+	}
+
+	tags := applyTagChanges(splitTags(tagsCSV), add, remove)
+
+	updateQuery := fmt.Sprintf("UPDATE avatar_jobs SET tags = %s WHERE id = %s", r.ph(1), r.ph(2))
+	if _, err := tx.ExecContext(ctx, updateQuery, joinTags(tags), id); err != nil {
+		return nil, fmt.Errorf("avatar: write tags for job %s: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("avatar: commit tag update for job %s: %w", id, err)
+	}
+
+	return r.Get(ctx, id)
+}
+
+// Delete implements JobRepository.
+func (r *sqlJobRepository) Delete(ctx context.Context, id string) error {
+	query := fmt.Sprintf("DELETE FROM avatar_jobs WHERE id = %s", r.ph(1))
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("avatar: delete job %s: %w", id, err)
+	}
+	return nil
+}
+
+// CountByStatus implements JobRepository.
+func (r *sqlJobRepository) CountByStatus(ctx context.Context) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT status, COUNT(*) FROM avatar_jobs GROUP BY status")
+	if err != nil {
+		return nil, fmt.Errorf("avatar: count jobs by status: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("avatar: scan status count: %w", err)
+		}
+		counts[status] = count
+	}
+	return counts, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanJob(row rowScanner) (*AvatarJob, error) {
+	var job AvatarJob
+	var source, tagsCSV string
+	var createdAt time.Time
+	if err := row.Scan(&job.ID, &job.UserID, &job.Status, &job.InputData, &job.OutputURL, &job.StorageACL,
+		&createdAt, &job.ErrorMessage, &source, &job.SourceTopic, &tagsCSV); err != nil {
+		return nil, err
+	}
+	job.CreatedAt = createdAt
+	job.Source = SourceType(source)
+	job.Tags = splitTags(tagsCSV)
+	return &job, nil
+}
+
+func joinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
+
+func splitTags(csv string) []string {
+	if csv == "" {
+		return nil
+	}
+	return strings.Split(csv, ",")
+}
+
+func applyTagChanges(tags, add, remove []string) []string {
+	removeSet := make(map[string]bool, len(remove))
+	for _, t := range remove {
+		removeSet[t] = true
+	}
+
+	kept := tags[:0]
+	for _, t := range tags {
+		if !removeSet[t] {
+			kept = append(kept, t)
+		}
+	}
+
+	existing := make(map[string]bool, len(kept))
+	for _, t := range kept {
+		existing[t] = true
+	}
+	for _, t := range add {
+		if !existing[t] {
+			kept = append(kept, t)
+			existing[t] = true
+		}
+	}
+	return kept
+}
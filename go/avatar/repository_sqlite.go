@@ -0,0 +1,42 @@
+package avatar
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteJobRepository is a JobRepository backed by a local SQLite database.
+type SQLiteJobRepository struct {
+	*sqlJobRepository
+	db *sql.DB
+}
+
+// NewSQLiteJobRepository opens dsn (a sqlite3 DSN, e.g. "jobs.db" or
+// "file::memory:?cache=shared"), configures the connection pool, and runs
+// pending migrations before returning.
+func NewSQLiteJobRepository(dsn string) (*SQLiteJobRepository, error) {
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("avatar: open sqlite database: %w", err)
+	}
+	// SQLite only supports one writer at a time; a single connection
+	// avoids "database is locked" errors under concurrent workers.
+	db.SetMaxOpenConns(1)
+
+	if err := runMigrations(db, "sqlite3"); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteJobRepository{
+		sqlJobRepository: &sqlJobRepository{db: db, placeholder: func(int) string { return "?" }},
+		db:               db,
+	}, nil
+}
+
+// Close closes the underlying database connection.
+func (r *SQLiteJobRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,163 @@
+package avatar
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+// testJobRepositoryContract exercises the JobRepository contract against
+// repo, so every backend (memory, SQLite, Postgres) is held to the same
+// behavior.
+func testJobRepositoryContract(t *testing.T, repo JobRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	job := NewAvatarJob("job-1", "user-1", "pending", "a robot avatar", SourceHTTP)
+	if err := repo.Save(ctx, job); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := repo.Get(ctx, "job-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got == nil || got.UserID != "user-1" {
+		t.Fatalf("Get returned %+v, want job-1 for user-1", got)
+	}
+
+	if _, err := repo.Get(ctx, "does-not-exist"); err != nil {
+		t.Fatalf("Get of unknown id should not error, got %v", err)
+	}
+
+	url := "https://avatars.example.com/job-1.png"
+	updated, err := repo.UpdateStatus(ctx, "job-1", "completed", &url, "private", nil)
+	if err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if updated.Status != "completed" || updated.OutputURL == nil || *updated.OutputURL != url {
+		t.Fatalf("UpdateStatus returned %+v, want completed with output URL", updated)
+	}
+	got, _ = repo.Get(ctx, "job-1")
+	if got.Status != "completed" || got.OutputURL == nil || *got.OutputURL != url {
+		t.Fatalf("UpdateStatus did not persist: %+v", got)
+	}
+
+	if _, err := repo.UpdateStatus(ctx, "missing-job", "completed", nil, "", nil); err == nil {
+		t.Fatalf("UpdateStatus of unknown id should error")
+	}
+
+	tagged, err := repo.UpdateTags(ctx, "job-1", []string{"featured", "robot"}, nil)
+	if err != nil {
+		t.Fatalf("UpdateTags add: %v", err)
+	}
+	if len(tagged.Tags) != 2 {
+		t.Fatalf("UpdateTags add = %v, want 2 tags", tagged.Tags)
+	}
+
+	tagged, err = repo.UpdateTags(ctx, "job-1", nil, []string{"robot"})
+	if err != nil {
+		t.Fatalf("UpdateTags remove: %v", err)
+	}
+	if len(tagged.Tags) != 1 || tagged.Tags[0] != "featured" {
+		t.Fatalf("UpdateTags remove = %v, want [featured]", tagged.Tags)
+	}
+
+	job2 := NewAvatarJob("job-2", "user-2", "pending", "a cat avatar", SourceKafka)
+	job2.SourceTopic = "avatar.jobs.inbound"
+	if err := repo.Save(ctx, job2); err != nil {
+		t.Fatalf("Save job-2: %v", err)
+	}
+	if _, err := repo.UpdateStatus(ctx, "job-2", "rejected", nil, "", strPtr("unsafe content")); err != nil {
+		t.Fatalf("UpdateStatus job-2: %v", err)
+	}
+
+	jobs, total, err := repo.List(ctx, JobFilter{Status: "completed"})
+	if err != nil {
+		t.Fatalf("List by status: %v", err)
+	}
+	if total != 1 || len(jobs) != 1 || jobs[0].ID != "job-1" {
+		t.Fatalf("List by status = %+v (total %d), want just job-1", jobs, total)
+	}
+
+	jobs, total, err = repo.List(ctx, JobFilter{})
+	if err != nil {
+		t.Fatalf("List all: %v", err)
+	}
+	if total != 2 || len(jobs) != 2 {
+		t.Fatalf("List all = %d jobs (total %d), want 2", len(jobs), total)
+	}
+
+	counts, err := repo.CountByStatus(ctx)
+	if err != nil {
+		t.Fatalf("CountByStatus: %v", err)
+	}
+	if counts["completed"] != 1 || counts["rejected"] != 1 {
+		t.Fatalf("CountByStatus = %+v, want completed:1 rejected:1", counts)
+	}
+
+	if err := repo.Delete(ctx, "job-2"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if got, _ := repo.Get(ctx, "job-2"); got != nil {
+		t.Fatalf("job-2 still present after Delete: %+v", got)
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestMemoryJobRepository_Contract(t *testing.T) {
+	testJobRepositoryContract(t, NewMemoryJobRepository())
+}
+
+func TestSQLiteJobRepository_Contract(t *testing.T) {
+	repo, err := NewSQLiteJobRepository("file::memory:?cache=shared")
+	if err != nil {
+		t.Fatalf("NewSQLiteJobRepository: %v", err)
+	}
+	defer repo.Close()
+
+	testJobRepositoryContract(t, repo)
+}
+
+func TestPostgresJobRepository_Contract(t *testing.T) {
+	dsn := os.Getenv("AVATAR_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("set AVATAR_TEST_POSTGRES_DSN to run the Postgres repository contract test")
+	}
+
+	repo, err := NewPostgresJobRepository(dsn)
+	if err != nil {
+		t.Fatalf("NewPostgresJobRepository: %v", err)
+	}
+	defer repo.Close()
+
+	testJobRepositoryContract(t, repo)
+}
+
+// FuzzSaveAndGet checks that any user-controlled InputData round-trips
+// through Save/Get unchanged, across the in-memory repository.
+func FuzzSaveAndGet(f *testing.F) {
+	f.Add("a friendly robot avatar")
+	f.Add("")
+	f.Add("unicode: 🤖🎨")
+
+	repo := NewMemoryJobRepository()
+	f.Fuzz(func(t *testing.T, inputData string) {
+		ctx := context.Background()
+		job := NewAvatarJob("fuzz-job", "fuzz-user", "pending", inputData, SourceHTTP)
+		job.CreatedAt = time.Now()
+
+		if err := repo.Save(ctx, job); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		got, err := repo.Get(ctx, "fuzz-job")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.InputData != inputData {
+			t.Fatalf("round-tripped InputData = %q, want %q", got.InputData, inputData)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package avatar
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy configures retry-with-backoff for CallModerationAPI. Attempts
+// are spaced by InitialBackoff, multiplied by Multiplier each time up to
+// MaxBackoff, and jittered by +/- Jitter of the computed delay. Budget caps
+// the total wall-clock time spent retrying, regardless of MaxAttempts.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	Budget         time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy CallModerationAPI uses unless
+// a different one is configured: up to 4 attempts, starting at 200ms and
+// doubling to a 2s cap, +/-20% jitter, within a 10s overall budget.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		Budget:         10 * time.Second,
+	}
+}
+
+// backoff computes the delay before the given retry (1-indexed, so
+// backoff(1) is the wait before the second attempt), applying the
+// exponential multiplier, the max cap, and jitter.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= p.Multiplier
+	}
+	if max := float64(p.MaxBackoff); delay > max {
+		delay = max
+	}
+
+	if p.Jitter > 0 {
+		spread := delay * p.Jitter
+		delay += (rand.Float64()*2 - 1) * spread
+	}
+	if delay < 0 {
+		delay = 0
+	}
+	return time.Duration(delay)
+}
+
+// retryableStatus reports whether status warrants another attempt: network
+// errors are always retried by the caller before status is known; here we
+// classify HTTP statuses as retryable only for 429 and 5xx.
+func retryableStatus(status int) bool {
+	return status == 429 || status >= 500
+}
@@ -0,0 +1,55 @@
+package avatar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryableStatus(t *testing.T) {
+	cases := map[int]bool{
+		200: false,
+		400: false,
+		404: false,
+		429: true,
+		500: true,
+		503: true,
+	}
+	for status, want := range cases {
+		if got := retryableStatus(status); got != want {
+			t.Errorf("retryableStatus(%d) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffCapsAtMaxBackoff(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0,
+	}
+	if got := p.backoff(1); got != 100*time.Millisecond {
+		t.Errorf("backoff(1) = %v, want 100ms", got)
+	}
+	if got := p.backoff(2); got != 200*time.Millisecond {
+		t.Errorf("backoff(2) = %v, want 200ms", got)
+	}
+	if got := p.backoff(5); got != 300*time.Millisecond {
+		t.Errorf("backoff(5) = %v, want capped at 300ms", got)
+	}
+}
+
+func TestRetryPolicy_BackoffJitterStaysWithinSpread(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     1,
+		Jitter:         0.2,
+	}
+	for i := 0; i < 20; i++ {
+		got := p.backoff(1)
+		if got < 80*time.Millisecond || got > 120*time.Millisecond {
+			t.Fatalf("backoff(1) = %v, want within +/-20%% of 100ms", got)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package avatar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AvatarProcessingService handles avatar generation and moderation. Jobs are
+// accepted from any number of JobSources, pushed onto a bounded JobQueue,
+// and processed asynchronously by a fixed pool of workers.
+type AvatarProcessingService struct {
+	moderationAPIURL string
+	apiToken         string
+	httpClient       *http.Client
+
+	repo JobRepository
+
+	queue    *JobQueue
+	outbound OutboundPublisher
+	sources  []JobSource
+	storage  AvatarStorage
+
+	retryPolicy RetryPolicy
+	breaker     *CircuitBreaker
+
+	cancel context.CancelFunc
+}
+
+// NewAvatarProcessingService creates a new avatar processing service backed
+// by a worker pool of the given size and a bounded job queue. outbound may
+// be nil, in which case terminal status changes are not published anywhere.
+// storage is where generated avatar bytes are uploaded; construct it (e.g.
+// via NewS3AvatarStorage) before calling this so an invalid ACL fails fast.
+// repo persists jobs; pass NewMemoryJobRepository() for local development,
+// or NewSQLiteJobRepository/NewPostgresJobRepository for durable storage.
+func NewAvatarProcessingService(moderationAPIURL, apiToken string, workers, queueCapacity int, outbound OutboundPublisher, storage AvatarStorage, repo JobRepository) *AvatarProcessingService {
+	if outbound == nil {
+		outbound = noopOutboundPublisher{}
+	}
+
+	s := &AvatarProcessingService{
+		moderationAPIURL: moderationAPIURL,
+		apiToken:         apiToken,
+		httpClient:       &http.Client{Timeout: 5 * time.Second},
+		repo:             repo,
+		outbound:         outbound,
+		storage:          storage,
+		retryPolicy:      DefaultRetryPolicy(),
+		breaker:          NewCircuitBreaker(5, 30*time.Second),
+	}
+	s.queue = NewJobQueue(queueCapacity, workers, s.process)
+	return s
+}
+
+// Start launches the worker pool and every registered JobSource. It must be
+// called before jobs are submitted through Kafka. SubmitJob enqueues
+// directly, so HTTP submissions are accepted before Start, but they are not
+// processed until the workers are running.
+func (s *AvatarProcessingService) Start(ctx context.Context, sources ...JobSource) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.sources = sources
+
+	// Workers run on a context that is not cancelled along with ctx, so
+	// jobs still queued when ctx ends are processed by Shutdown's drain.
+	s.queue.Start(context.WithoutCancel(ctx))
+	for _, src := range sources {
+		go func(src JobSource) {
+			if err := src.Run(ctx, s.queue); err != nil {
+				fmt.Printf("avatar: job source %s stopped: %v\n", src.Name(), err)
+			}
+		}(src)
+	}
+}
+
+// Shutdown stops all job sources and drains in-flight jobs from the queue,
+// waiting up to the deadline on ctx before giving up.
+func (s *AvatarProcessingService) Shutdown(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	for _, src := range s.sources {
+		_ = src.Stop()
+	}
+	return s.queue.Shutdown(ctx)
+}
+
+// SubmitJob submits a new avatar generation job for asynchronous processing
+// and returns immediately with the job in "pending" status. Moderation and
+// status transitions happen on a worker goroutine.
+//
+// Args:
+//
+//	userID: ID of the user requesting the avatar
+//	inputData: User's avatar generation prompt/description
+//
+// Returns:
+//
+//	*AvatarJob: The newly created, pending job
+//	error: ErrQueueFull if the worker pool is saturated
+func (s *AvatarProcessingService) SubmitJob(userID, inputData string) (*AvatarJob, error) {
+	job := NewAvatarJob(uuid.NewString(), userID, "pending", inputData, SourceHTTP)
+
+	if err := s.repo.Save(context.Background(), job); err != nil {
+		return nil, fmt.Errorf("avatar: submit job %s: %w", job.ID, err)
+	}
+
+	if err := s.queue.Enqueue(job); err != nil {
+		return nil, fmt.Errorf("avatar: submit job %s: %w", job.ID, err)
+	}
+	return job, nil
+}
+
+// GetJobStatus retrieves the current status of a job
+//
+// Args:
+//
+//	jobID: ID of the job to check
+//
+// Returns:
+//
+//	*AvatarJob: The job object if found, nil otherwise
+//	error: Any error that occurred during retrieval
+func (s *AvatarProcessingService) GetJobStatus(jobID string) (*AvatarJob, error) {
+	return s.repo.Get(context.Background(), jobID)
+}
+
+// process runs a job end to end: moderate its prompt, generate and upload
+// the avatar if approved, update its status, and publish the terminal
+// status to the outbound source. It is
+// invoked by JobQueue workers and must tolerate concurrent execution across
+// different jobs.
+func (s *AvatarProcessingService) process(ctx context.Context, job *AvatarJob) {
+	resp, err := s.CallModerationAPI(job.InputData, job.UserID)
+	if err != nil {
+		status := "failed"
+		var modErr *ModerationError
+		msg := err.Error()
+		if errors.As(err, &modErr) {
+			msg = modErr.JSON()
+			if modErr.Code == ErrCodeModerationUnavailable {
+				status = "moderation_unavailable"
+			}
+		}
+		s.finish(ctx, job, status, nil, "", &msg)
+		return
+	}
+
+	if !resp.IsApproved {
+		reason := resp.Reason
+		s.finish(ctx, job, "rejected", nil, "", &reason)
+	} else {
+		url, acl, err := s.storage.Upload(ctx, avatarStorageKey(job.ID), s.generateAvatarBytes(job.InputData))
+		if err != nil {
+			msg := err.Error()
+			s.finish(ctx, job, "failed", nil, "", &msg)
+		} else {
+			s.finish(ctx, job, "completed", &url, acl, nil)
+		}
+	}
+}
+
+// finish applies a terminal status transition and persists it through the
+// repository, so the transition is atomic and durable even across a worker
+// restart. The repository is the sole mutator of job state: finish never
+// touches job's fields directly, since MemoryJobRepository.Save keeps the
+// very pointer that SubmitJob enqueues for the worker, and mutating it
+// outside UpdateStatus's lock would race a concurrent GetJobStatus/ListJobs
+// read. It publishes the
+// repository's own copy of the updated job to outbound instead.
+func (s *AvatarProcessingService) finish(ctx context.Context, job *AvatarJob, status string, outputURL *string, storageACL string, errMsg *string) {
+	updated, err := s.repo.UpdateStatus(ctx, job.ID, status, outputURL, storageACL, errMsg)
+	if err != nil {
+		fmt.Printf("avatar: persist status transition for job %s: %v\n", job.ID, err)
+		return
+	}
+
+	if pubErr := s.outbound.Publish(ctx, updated); pubErr != nil {
+		fmt.Printf("avatar: publish terminal status for job %s: %v\n", updated.ID, pubErr)
+	}
+}
+
+// generateAvatarBytes simulates avatar generation, producing placeholder
+// PNG bytes for prompt. The real image model is out of scope here; this
+// keeps the storage upload path exercised end to end.
+//
+// Args:
+//
+//	prompt: User's avatar description
+//
+// Returns:
+//
+//	[]byte: Placeholder avatar bytes to upload
+func (s *AvatarProcessingService) generateAvatarBytes(prompt string) []byte {
+	pngHeader := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
+	return append(pngHeader, []byte(prompt)...)
+}
+
+// avatarStorageKey is the object key a completed job's avatar is uploaded
+// under. GetUserAvatar rederives it from the job ID to read the bytes back
+// through AvatarStorage.Download rather than OutputURL, since a private ACL
+// (the configured default) makes OutputURL unreachable by a bare HTTP GET.
+func avatarStorageKey(jobID string) string {
+	return jobID + ".png"
+}
@@ -0,0 +1,41 @@
+package avatar
+
+import "context"
+
+// JobSource is a pluggable ingestion path that decodes inbound submissions
+// into AvatarJobs and pushes them onto a JobQueue. HTTPJobSource and
+// KafkaJobSource are the two implementations; additional sources can be
+// added without touching AvatarProcessingService.
+type JobSource interface {
+	// Name identifies the source for traceability on AvatarJob.Source.
+	Name() SourceType
+	// Run consumes from the source until ctx is cancelled, pushing decoded
+	// jobs onto queue as they arrive.
+	Run(ctx context.Context, queue *JobQueue) error
+	// Stop releases any resources held by the source (connections,
+	// consumer groups, etc).
+	Stop() error
+}
+
+// HTTPJobSource represents the synchronous HTTP submission path. Unlike
+// KafkaJobSource it has no background consume loop: AvatarProcessingService
+// pushes jobs onto the queue directly from SubmitJob as requests arrive.
+// It still satisfies JobSource so the service can start/stop all sources
+// uniformly during startup and graceful shutdown.
+type HTTPJobSource struct{}
+
+// NewHTTPJobSource creates an HTTPJobSource.
+func NewHTTPJobSource() *HTTPJobSource { return &HTTPJobSource{} }
+
+// Name implements JobSource.
+func (s *HTTPJobSource) Name() SourceType { return SourceHTTP }
+
+// Run blocks until ctx is cancelled; HTTP jobs are enqueued out-of-band by
+// SubmitJob rather than through this loop.
+func (s *HTTPJobSource) Run(ctx context.Context, _ *JobQueue) error {
+	<-ctx.Done()
+	return nil
+}
+
+// Stop is a no-op for the HTTP source.
+func (s *HTTPJobSource) Stop() error { return nil }
@@ -0,0 +1,41 @@
+package avatar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AvatarStorage uploads generated avatar bytes to an object store and
+// returns the canonical URL the bytes can be retrieved from. S3AvatarStorage
+// and GCSAvatarStorage are the two production implementations.
+type AvatarStorage interface {
+	// Upload stores data under key, detecting its content type, and
+	// returns the URL it is reachable at plus the ACL it was stored with.
+	Upload(ctx context.Context, key string, data []byte) (url string, acl string, err error)
+
+	// Download retrieves the bytes previously stored under key, along with
+	// their detected content type. GetUserAvatar reads through this instead
+	// of fetching OutputURL directly, since a private ACL (the configured
+	// default) makes the object unreachable by a bare HTTP GET.
+	Download(ctx context.Context, key string) (data []byte, contentType string, err error)
+}
+
+// detectContentType is shared by every AvatarStorage implementation so
+// content-type handling stays consistent across backends.
+func detectContentType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// validateACL checks acl against allowed, returning a descriptive error if
+// it is not one of the values the backend accepts. Callers should invoke
+// this at construction time so a misconfigured ACL fails fast instead of on
+// the first upload.
+func validateACL(backend, acl string, allowed []string) error {
+	for _, a := range allowed {
+		if acl == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("avatar: invalid %s ACL %q, must be one of %v", backend, acl, allowed)
+}
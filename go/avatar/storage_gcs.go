@@ -0,0 +1,72 @@
+package avatar
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/storage"
+)
+
+// allowedGCSACLs are the predefined ACLs GCSAvatarStorage accepts, matching
+// the PredefinedACL values the GCS JSON API supports for object inserts.
+var allowedGCSACLs = []string{
+	"authenticatedRead",
+	"private",
+	"projectPrivate",
+	"publicRead",
+	"publicReadWrite",
+}
+
+// GCSAvatarStorage uploads avatar bytes to a Google Cloud Storage bucket.
+type GCSAvatarStorage struct {
+	client *storage.Client
+	bucket string
+	acl    string
+}
+
+// NewGCSAvatarStorage creates a GCSAvatarStorage writing to bucket with the
+// given predefined ACL (read from AVATAR_GS_ACL by callers). It validates
+// acl against the set GCS supports and returns an error immediately if it
+// is not recognized, rather than failing on the first upload.
+func NewGCSAvatarStorage(client *storage.Client, bucket, acl string) (*GCSAvatarStorage, error) {
+	if err := validateACL("GCS", acl, allowedGCSACLs); err != nil {
+		return nil, err
+	}
+	return &GCSAvatarStorage{client: client, bucket: bucket, acl: acl}, nil
+}
+
+// Upload implements AvatarStorage.
+func (st *GCSAvatarStorage) Upload(ctx context.Context, key string, data []byte) (string, string, error) {
+	contentType := detectContentType(data)
+
+	obj := st.client.Bucket(st.bucket).Object(key)
+	w := obj.NewWriter(ctx)
+	w.ContentType = contentType
+	w.PredefinedACL = st.acl
+
+	if _, err := w.Write(data); err != nil {
+		return "", "", fmt.Errorf("avatar: gcs upload %s/%s: %w", st.bucket, key, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", "", fmt.Errorf("avatar: gcs finalize upload %s/%s: %w", st.bucket, key, err)
+	}
+
+	url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", st.bucket, key)
+	return url, st.acl, nil
+}
+
+// Download implements AvatarStorage.
+func (st *GCSAvatarStorage) Download(ctx context.Context, key string) ([]byte, string, error) {
+	r, err := st.client.Bucket(st.bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("avatar: gcs download %s/%s: %w", st.bucket, key, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("avatar: read gcs object %s/%s: %w", st.bucket, key, err)
+	}
+	return data, detectContentType(data), nil
+}
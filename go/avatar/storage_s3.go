@@ -0,0 +1,78 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// allowedS3ACLs are the canned ACLs S3AvatarStorage accepts, matching the
+// set S3 itself supports for PutObject.
+var allowedS3ACLs = []string{
+	"private",
+	"public-read",
+	"public-read-write",
+	"authenticated-read",
+	"bucket-owner-read",
+	"bucket-owner-full-control",
+}
+
+// S3AvatarStorage uploads avatar bytes to an S3 bucket.
+type S3AvatarStorage struct {
+	client *s3.Client
+	bucket string
+	acl    types.ObjectCannedACL
+}
+
+// NewS3AvatarStorage creates an S3AvatarStorage writing to bucket with the
+// given canned ACL (read from AVATAR_S3_ACL by callers). It validates acl
+// against the set S3 supports and returns an error immediately if it is not
+// recognized, rather than failing on the first upload.
+func NewS3AvatarStorage(client *s3.Client, bucket, acl string) (*S3AvatarStorage, error) {
+	if err := validateACL("S3", acl, allowedS3ACLs); err != nil {
+		return nil, err
+	}
+	return &S3AvatarStorage{client: client, bucket: bucket, acl: types.ObjectCannedACL(acl)}, nil
+}
+
+// Upload implements AvatarStorage.
+func (st *S3AvatarStorage) Upload(ctx context.Context, key string, data []byte) (string, string, error) {
+	contentType := detectContentType(data)
+
+	_, err := st.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(st.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ACL:         st.acl,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("avatar: s3 upload %s/%s: %w", st.bucket, key, err)
+	}
+
+	url := fmt.Sprintf("https://%s.s3.amazonaws.com/%s", st.bucket, key)
+	return url, string(st.acl), nil
+}
+
+// Download implements AvatarStorage.
+func (st *S3AvatarStorage) Download(ctx context.Context, key string) ([]byte, string, error) {
+	out, err := st.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(st.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("avatar: s3 download %s/%s: %w", st.bucket, key, err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("avatar: read s3 object %s/%s: %w", st.bucket, key, err)
+	}
+	return data, detectContentType(data), nil
+}
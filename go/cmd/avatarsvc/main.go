@@ -0,0 +1,139 @@
+// Command avatarsvc runs the avatar processing service: an async worker
+// pool that moderates generated avatars submitted over HTTP or consumed
+// from Kafka.
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	gcstorage "cloud.google.com/go/storage"
+	"github.com/gorilla/mux"
+
+	"backend-interview-challenge/api"
+	"backend-interview-challenge/avatar"
+)
+
+func main() {
+	storage, err := newAvatarStorage()
+	if err != nil {
+		log.Fatalf("configure avatar storage: %v", err)
+	}
+
+	repo, err := newJobRepository()
+	if err != nil {
+		log.Fatalf("configure job repository: %v", err)
+	}
+
+	service := avatar.NewAvatarProcessingService(
+		envOr("MODERATION_API_URL", "https://api.example.com"),
+		os.Getenv("MODERATION_API_TOKEN"),
+		8,   // workers
+		256, // queue capacity
+		outboundPublisher(),
+		storage,
+		repo,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	sources := []avatar.JobSource{avatar.NewHTTPJobSource()}
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		sources = append(sources, avatar.NewKafkaJobSource(avatar.KafkaJobSourceConfig{
+			Brokers: strings.Split(brokers, ","),
+			Topic:   envOr("KAFKA_INBOUND_TOPIC", "avatar.jobs.inbound"),
+			GroupID: envOr("KAFKA_GROUP_ID", "avatarsvc"),
+			Repo:    repo,
+		}))
+	}
+
+	service.Start(ctx, sources...)
+
+	router := mux.NewRouter()
+	api.NewRestApi(service, os.Getenv("AVATAR_API_TOKEN")).MountRoutes(router)
+
+	httpServer := &http.Server{
+		Addr:    envOr("AVATAR_HTTP_ADDR", ":8080"),
+		Handler: router,
+	}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("http server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("shutting down, draining in-flight jobs...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("http server shutdown: %v", err)
+	}
+	if err := service.Shutdown(shutdownCtx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+}
+
+// newAvatarStorage builds the AvatarStorage backend selected by
+// AVATAR_STORAGE_BACKEND ("s3" or "gcs", defaulting to "s3").
+func newAvatarStorage() (avatar.AvatarStorage, error) {
+	ctx := context.Background()
+	bucket := os.Getenv("AVATAR_BUCKET")
+
+	switch envOr("AVATAR_STORAGE_BACKEND", "s3") {
+	case "gcs":
+		client, err := gcstorage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return avatar.NewGCSAvatarStorage(client, bucket, envOr("AVATAR_GS_ACL", "private"))
+	default:
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return avatar.NewS3AvatarStorage(s3.NewFromConfig(cfg), bucket, envOr("AVATAR_S3_ACL", "private"))
+	}
+}
+
+// newJobRepository builds the JobRepository selected by
+// AVATAR_REPOSITORY_BACKEND ("memory", "sqlite", or "postgres", defaulting
+// to "memory").
+func newJobRepository() (avatar.JobRepository, error) {
+	switch envOr("AVATAR_REPOSITORY_BACKEND", "memory") {
+	case "sqlite":
+		return avatar.NewSQLiteJobRepository(envOr("AVATAR_SQLITE_DSN", "avatarsvc.db"))
+	case "postgres":
+		return avatar.NewPostgresJobRepository(os.Getenv("AVATAR_POSTGRES_DSN"))
+	default:
+		return avatar.NewMemoryJobRepository(), nil
+	}
+}
+
+func outboundPublisher() avatar.OutboundPublisher {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		return nil
+	}
+	return avatar.NewKafkaOutboundPublisher(
+		strings.Split(brokers, ","),
+		envOr("KAFKA_OUTBOUND_TOPIC", "avatar.jobs.status"),
+	)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}